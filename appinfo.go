@@ -0,0 +1,14 @@
+package httpd
+
+import "net/http"
+
+// AppInfo adds App-Name and App-Version headers to every response.
+func AppInfo(name string, version string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("App-Name", name)
+			w.Header().Set("App-Version", version)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
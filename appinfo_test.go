@@ -0,0 +1,32 @@
+package httpd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAppInfo(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(AppInfo("myapp", "1.2.3"))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("App-Name"); got != "myapp" {
+		t.Errorf("expected App-Name myapp, got %s", got)
+	}
+
+	if got := w.Header().Get("App-Version"); got != "1.2.3" {
+		t.Errorf("expected App-Version 1.2.3, got %s", got)
+	}
+}
@@ -0,0 +1,35 @@
+package httpd
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+)
+
+// BasicAuth protects routes with HTTP Basic authentication, checking
+// credentials against accounts (username -> password). Requests without
+// valid credentials receive a 401 with a WWW-Authenticate header naming
+// realm.
+func BasicAuth(realm string, accounts map[string]string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, pass, ok := r.BasicAuth()
+			if !ok || !basicAuthAccountValid(accounts, user, pass) {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Basic realm=%q`, realm))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func basicAuthAccountValid(accounts map[string]string, user string, pass string) bool {
+	want, ok := accounts[user]
+	if !ok {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(want), []byte(pass)) == 1
+}
@@ -0,0 +1,76 @@
+package httpd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBasicAuthSuccess(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(BasicAuth("test", map[string]string{"alice": "secret"}))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.SetBasicAuth("alice", "secret")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestBasicAuthFailure(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(BasicAuth("test", map[string]string{"alice": "secret"}))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.SetBasicAuth("alice", "wrong")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+
+	if got := w.Header().Get("WWW-Authenticate"); got != `Basic realm="test"` {
+		t.Errorf(`expected WWW-Authenticate Basic realm="test", got %s`, got)
+	}
+}
+
+func TestBasicAuthMissingCredentials(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(BasicAuth("test", map[string]string{"alice": "secret"}))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
@@ -0,0 +1,167 @@
+package httpd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Bind decodes r into a value of type T based on its Content-Type:
+// application/json is JSON-decoded, application/x-www-form-urlencoded and
+// multipart/form-data are form-decoded, and anything else (typically GET
+// requests) is decoded from the URL query string. Form and query decoding
+// populate exported struct fields using a `form:"name"` tag, falling back
+// to the lowercased field name.
+//
+// Decoding failures are returned as a 400 Error so they flow through
+// DefaultErrorHandler unchanged.
+func Bind[T any](r *http.Request) (T, error) {
+	var v T
+
+	ct := r.Header.Get("Content-Type")
+
+	switch {
+	case strings.HasPrefix(ct, "application/json"):
+		if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+			return v, NewError(http.StatusBadRequest, err, true)
+		}
+	case strings.HasPrefix(ct, "application/x-www-form-urlencoded"), strings.HasPrefix(ct, "multipart/form-data"):
+		var err error
+		if strings.HasPrefix(ct, "multipart/form-data") {
+			err = r.ParseMultipartForm(32 << 20)
+		} else {
+			err = r.ParseForm()
+		}
+		if err != nil {
+			return v, NewError(http.StatusBadRequest, err, true)
+		}
+
+		if err := bindValues(&v, r.Form); err != nil {
+			return v, NewError(http.StatusBadRequest, err, true)
+		}
+	default:
+		if err := bindValues(&v, r.URL.Query()); err != nil {
+			return v, NewError(http.StatusBadRequest, err, true)
+		}
+	}
+
+	return v, nil
+}
+
+// Validator is implemented by types that can validate their own field
+// values after binding.
+type Validator interface {
+	Validate() error
+}
+
+// BindAndValidate binds r into a value of type T via Bind, then calls its
+// Validate method. A validation failure is returned as a 400 Error.
+func BindAndValidate[T Validator](r *http.Request) (T, error) {
+	v, err := Bind[T](r)
+	if err != nil {
+		return v, err
+	}
+
+	if err := v.Validate(); err != nil {
+		return v, NewError(http.StatusBadRequest, err, true)
+	}
+
+	return v, nil
+}
+
+// Handler adapts fn, a handler taking a bound request of type Req and
+// returning a response of type Res, into a Route: the request is bound via
+// Bind, fn is invoked, and its result is JSON-encoded with a 200 status.
+// Errors from binding or fn flow through unchanged, so they still reach
+// DefaultErrorHandler.
+func Handler[Req any, Res any](fn func(context.Context, Req) (Res, error)) Route {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		req, err := Bind[Req](r)
+		if err != nil {
+			return err
+		}
+
+		res, err := fn(r.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return RespondJSON(w, http.StatusOK, res)
+	}
+}
+
+func bindValues(v any, values url.Values) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("httpd: bind target must be a pointer to a struct")
+	}
+
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get("form")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+
+		raw := values.Get(key)
+		if raw == "" {
+			continue
+		}
+
+		if err := bindField(rv.Field(i), raw); err != nil {
+			return fmt.Errorf("httpd: binding field %s: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func bindField(f reflect.Value, raw string) error {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		f.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field kind %s", f.Kind())
+	}
+
+	return nil
+}
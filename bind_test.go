@@ -0,0 +1,154 @@
+package httpd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type bindTestUser struct {
+	Name string `form:"name"`
+	Age  int    `form:"age"`
+}
+
+func TestBindJSON(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	body := strings.NewReader(`{"name":"alice","age":30}`)
+	req := httptest.NewRequest("POST", "/test", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	u, err := Bind[bindTestUser](req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Name != "alice" || u.Age != 30 {
+		t.Errorf("expected {alice 30}, got %+v", u)
+	}
+}
+
+func TestBindJSONError(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	body := strings.NewReader(`not json`)
+	req := httptest.NewRequest("POST", "/test", body)
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err := Bind[bindTestUser](req)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	e, ok := err.(Error)
+	if !ok || e.Status() != http.StatusBadRequest {
+		t.Errorf("expected 400 Error, got %v", err)
+	}
+}
+
+func TestBindForm(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	body := strings.NewReader("name=bob&age=25")
+	req := httptest.NewRequest("POST", "/test", body)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	u, err := Bind[bindTestUser](req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Name != "bob" || u.Age != 25 {
+		t.Errorf("expected {bob 25}, got %+v", u)
+	}
+}
+
+func TestBindQuery(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	req := httptest.NewRequest("GET", "/test?name=carol&age=40", nil)
+
+	u, err := Bind[bindTestUser](req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u.Name != "carol" || u.Age != 40 {
+		t.Errorf("expected {carol 40}, got %+v", u)
+	}
+}
+
+type bindTestValidated struct {
+	Name string `form:"name"`
+}
+
+func (v bindTestValidated) Validate() error {
+	if v.Name == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func TestBindAndValidateSuccess(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	req := httptest.NewRequest("GET", "/test?name=dan", nil)
+
+	v, err := BindAndValidate[bindTestValidated](req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Name != "dan" {
+		t.Errorf("expected name dan, got %s", v.Name)
+	}
+}
+
+func TestBindAndValidateFailure(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	req := httptest.NewRequest("GET", "/test", nil)
+
+	_, err := BindAndValidate[bindTestValidated](req)
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	e, ok := err.(Error)
+	if !ok || e.Status() != http.StatusBadRequest {
+		t.Errorf("expected 400 Error, got %v", err)
+	}
+}
+
+func TestTypedHandler(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+
+	r.Get("/users/{id}", Handler(func(ctx context.Context, req bindTestUser) (bindTestUser, error) {
+		return req, nil
+	}))
+
+	w := httptest.NewRecorder()
+	httpReq := httptest.NewRequest("GET", "/users/1?name=eve&age=22", nil)
+	r.ServeHTTP(w, httpReq)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	expected := `{"Name":"eve","Age":22}`
+	if w.Body.String() != expected {
+		t.Errorf("expected body %s, got %s", expected, w.Body.String())
+	}
+}
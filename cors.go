@@ -0,0 +1,128 @@
+package httpd
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CORSOptions configures CORS.
+type CORSOptions struct {
+	// AllowedOrigins is the set of origins allowed to access the resource.
+	// Use "*" to allow any origin. Per the CORS spec, browsers reject
+	// Access-Control-Allow-Origin: * on a credentialed response, so when
+	// AllowCredentials is true, "*" is ignored here and only origins
+	// listed explicitly are allowed.
+	AllowedOrigins []string
+
+	// AllowedMethods is the set of methods allowed in the actual request,
+	// sent in response to a preflight request. Defaults to GET, POST, PUT,
+	// PATCH, DELETE, OPTIONS.
+	AllowedMethods []string
+
+	// AllowedHeaders is the set of headers allowed in the actual request,
+	// sent in response to a preflight request. If empty, the headers
+	// requested via Access-Control-Request-Headers are reflected back.
+	AllowedHeaders []string
+
+	// ExposedHeaders is the set of headers made available to scripts
+	// running in the browser via Access-Control-Expose-Headers.
+	ExposedHeaders []string
+
+	// AllowCredentials sets Access-Control-Allow-Credentials: true.
+	AllowCredentials bool
+
+	// MaxAge is how long, in seconds, a preflight response can be cached.
+	MaxAge int
+}
+
+var defaultCORSAllowedMethods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// CORS implements Cross-Origin Resource Sharing, handling preflight
+// (OPTIONS) requests and setting the appropriate Access-Control-* headers
+// on actual requests.
+func CORS(opts CORSOptions) Middleware {
+	allowedMethods := opts.AllowedMethods
+	if len(allowedMethods) == 0 {
+		allowedMethods = defaultCORSAllowedMethods
+	}
+	allowedMethodsHeader := strings.Join(allowedMethods, ", ")
+	allowedHeadersHeader := strings.Join(opts.AllowedHeaders, ", ")
+	exposedHeadersHeader := strings.Join(opts.ExposedHeaders, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+
+			if origin != "" && corsOriginAllowed(opts.AllowedOrigins, origin, opts.AllowCredentials) {
+				w.Header().Add("Vary", "Origin")
+				w.Header().Set("Access-Control-Allow-Origin", corsAllowOriginValue(opts.AllowedOrigins, origin, opts.AllowCredentials))
+
+				if opts.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+
+				if exposedHeadersHeader != "" {
+					w.Header().Set("Access-Control-Expose-Headers", exposedHeadersHeader)
+				}
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", allowedMethodsHeader)
+
+				if allowedHeadersHeader != "" {
+					w.Header().Set("Access-Control-Allow-Headers", allowedHeadersHeader)
+				} else if reqHeaders := r.Header.Get("Access-Control-Request-Headers"); reqHeaders != "" {
+					w.Header().Set("Access-Control-Allow-Headers", reqHeaders)
+				}
+
+				if opts.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(opts.MaxAge))
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// corsOriginAllowed reports whether origin is allowed. When
+// allowCredentials is true, a "*" entry in allowed is ignored — the
+// wildcard-with-credentials combination is rejected by every browser, so
+// credentialed responses require an explicit allow-list.
+func corsOriginAllowed(allowed []string, origin string, allowCredentials bool) bool {
+	for _, a := range allowed {
+		if a == origin {
+			return true
+		}
+		if a == "*" && !allowCredentials {
+			return true
+		}
+	}
+
+	return false
+}
+
+func corsAllowOriginValue(allowed []string, origin string, allowCredentials bool) string {
+	if allowCredentials {
+		return origin
+	}
+
+	for _, a := range allowed {
+		if a == "*" {
+			return "*"
+		}
+	}
+
+	return origin
+}
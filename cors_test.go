@@ -0,0 +1,181 @@
+package httpd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSSimpleRequest(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(CORS(CORSOptions{AllowedOrigins: []string{"https://example.com"}}))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Origin", "https://example.com")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Errorf("expected Access-Control-Allow-Origin https://example.com, got %s", got)
+	}
+}
+
+func TestCORSDisallowedOrigin(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(CORS(CORSOptions{AllowedOrigins: []string{"https://example.com"}}))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Origin", "https://evil.com")
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no Access-Control-Allow-Origin, got %s", got)
+	}
+}
+
+func TestCORSPreflight(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(CORS(CORSOptions{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		MaxAge:         600,
+	}))
+
+	r.Post("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodOptions, "/test", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", w.Code)
+	}
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("expected Access-Control-Allow-Origin *, got %s", got)
+	}
+
+	if got := w.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+		t.Errorf("expected Access-Control-Allow-Methods GET, POST, got %s", got)
+	}
+
+	if got := w.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Errorf("expected Access-Control-Max-Age 600, got %s", got)
+	}
+}
+
+func TestCORSExplicitOriginWithCredentials(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(CORS(CORSOptions{
+		AllowedOrigins:   []string{"https://example.com"},
+		AllowCredentials: true,
+	}))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Origin", "https://example.com")
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Errorf("expected Access-Control-Allow-Origin to reflect the request origin, got %s", got)
+	}
+
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("expected Access-Control-Allow-Credentials true, got %s", got)
+	}
+
+	if got := w.Header().Values("Vary"); len(got) == 0 || got[0] != "Origin" {
+		t.Errorf("expected Vary: Origin, got %v", got)
+	}
+}
+
+func TestCORSWildcardOriginWithCredentialsRejected(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(CORS(CORSOptions{
+		AllowedOrigins:   []string{"*"},
+		AllowCredentials: true,
+	}))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Origin", "https://example.com")
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("expected no Access-Control-Allow-Origin for the unsafe */credentials combination, got %s", got)
+	}
+
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("expected no Access-Control-Allow-Credentials for the unsafe */credentials combination, got %s", got)
+	}
+}
+
+func TestCORSNonPreflightOptionsFallsThrough(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(CORS(CORSOptions{AllowedOrigins: []string{"*"}}))
+
+	r.Handle(http.MethodOptions, "/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, []byte("options handled"))
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodOptions, "/test", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	if w.Body.String() != "options handled" {
+		t.Errorf("expected the route's own OPTIONS handler to run, got %q", w.Body.String())
+	}
+}
@@ -0,0 +1,65 @@
+package httpd
+
+import "net/http"
+
+// Group is a sub-router that registers routes under a shared prefix with a
+// shared middleware chain, e.g.:
+//
+//	v1 := r.Group("/api/v1", auth)
+//	users := v1.Group("/users")
+//	users.Get("/{id}", getUser)
+type Group struct {
+	router *Router
+	prefix string
+	mw     []Middleware
+}
+
+// Group returns a sub-router of r whose routes are registered under prefix
+// and wrapped with mw (in addition to any middleware registered via
+// Router.Use, which always wraps the final handler).
+func (r *Router) Group(prefix string, mw ...Middleware) *Group {
+	return &Group{
+		router: r,
+		prefix: prefix,
+		mw:     append([]Middleware{}, mw...),
+	}
+}
+
+// Group returns a nested sub-router whose prefix and middleware chain
+// extend g's.
+func (g *Group) Group(prefix string, mw ...Middleware) *Group {
+	return &Group{
+		router: g.router,
+		prefix: g.prefix + prefix,
+		mw:     append(append([]Middleware{}, g.mw...), mw...),
+	}
+}
+
+func (g *Group) route(method string, pattern string, route Route, middleware ...Middleware) {
+	mw := append(append([]Middleware{}, g.mw...), middleware...)
+	g.router.route(method, g.prefix+pattern, route, mw...)
+}
+
+func (g *Group) Delete(pattern string, route Route, middleware ...Middleware) {
+	g.route(http.MethodDelete, pattern, route, middleware...)
+}
+
+func (g *Group) Get(pattern string, route Route, middleware ...Middleware) {
+	g.route(http.MethodGet, pattern, route, middleware...)
+}
+
+func (g *Group) Handle(method string, pattern string, route Route, middleware ...Middleware) {
+	g.route(method, pattern, route, middleware...)
+}
+
+func (g *Group) Patch(pattern string, route Route, middleware ...Middleware) {
+	g.route(http.MethodPatch, pattern, route, middleware...)
+}
+
+func (g *Group) Post(pattern string, route Route, middleware ...Middleware) {
+	g.route(http.MethodPost, pattern, route, middleware...)
+}
+
+func (g *Group) Put(pattern string, route Route, middleware ...Middleware) {
+	g.route(http.MethodPut, pattern, route, middleware...)
+}
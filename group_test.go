@@ -0,0 +1,116 @@
+package httpd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func markerMiddleware(name string, order *[]string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*order = append(*order, name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestGroupPrefix(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	v1 := r.Group("/api/v1")
+
+	v1.Get("/users/{id}", func(w http.ResponseWriter, req *http.Request) error {
+		id := req.PathValue("id")
+		return RespondJSON(w, http.StatusOK, map[string]string{"id": id})
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/v1/users/42", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	expected := `{"id":"42"}`
+	if w.Body.String() != expected {
+		t.Errorf("expected body %s, got %s", expected, w.Body.String())
+	}
+}
+
+func TestGroupMiddlewareOrder(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	var order []string
+
+	r := New()
+	r.Use(markerMiddleware("global", &order))
+
+	v1 := r.Group("/api/v1", markerMiddleware("group", &order))
+
+	v1.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	}, markerMiddleware("route", &order))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/v1/test", nil)
+	r.ServeHTTP(w, req)
+
+	expected := []string{"global", "group", "route"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected order %v, got %v", expected, order)
+	}
+
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("expected order %v, got %v", expected, order)
+			break
+		}
+	}
+}
+
+func TestGroupNested(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	var order []string
+
+	r := New()
+	v1 := r.Group("/api/v1", markerMiddleware("v1", &order))
+	users := v1.Group("/users", markerMiddleware("users", &order))
+
+	users.Get("/{id}", func(w http.ResponseWriter, req *http.Request) error {
+		id := req.PathValue("id")
+		return RespondJSON(w, http.StatusOK, map[string]string{"id": id})
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/v1/users/7", nil)
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	expected := `{"id":"7"}`
+	if w.Body.String() != expected {
+		t.Errorf("expected body %s, got %s", expected, w.Body.String())
+	}
+
+	expectedOrder := []string{"v1", "users"}
+	if len(order) != len(expectedOrder) {
+		t.Fatalf("expected order %v, got %v", expectedOrder, order)
+	}
+
+	for i := range expectedOrder {
+		if order[i] != expectedOrder[i] {
+			t.Errorf("expected order %v, got %v", expectedOrder, order)
+			break
+		}
+	}
+}
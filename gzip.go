@@ -0,0 +1,110 @@
+package httpd
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	level       int
+	gz          *gzip.Writer
+	wroteHeader bool
+	compress    bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+
+		if w.Header().Get("Content-Encoding") == "" {
+			if gz, err := gzip.NewWriterLevel(w.ResponseWriter, w.level); err == nil {
+				w.gz = gz
+				w.compress = true
+				w.Header().Set("Content-Encoding", "gzip")
+				w.Header().Add("Vary", "Accept-Encoding")
+				w.Header().Del("Content-Length")
+			}
+		}
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if !w.compress {
+		return w.ResponseWriter.Write(b)
+	}
+
+	return w.gz.Write(b)
+}
+
+func (w *gzipResponseWriter) Flush() {
+	if w.compress {
+		w.gz.Flush()
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *gzipResponseWriter) close() error {
+	if w.compress {
+		return w.gz.Close()
+	}
+
+	return nil
+}
+
+// acceptsGzip reports whether the client's Accept-Encoding header allows
+// gzip, honoring an explicit q=0 refusal (e.g. "gzip;q=0").
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := strings.Cut(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+
+		q := 1.0
+		if strings.TrimSpace(params) != "" {
+			for _, p := range strings.Split(params, ";") {
+				if v, ok := strings.CutPrefix(strings.TrimSpace(p), "q="); ok {
+					if parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+						q = parsed
+					}
+				}
+			}
+		}
+
+		return q > 0
+	}
+
+	return false
+}
+
+// Gzip gzip-encodes the response body when the client sends
+// Accept-Encoding: gzip, at the given compression level (see
+// compress/gzip for valid levels). It leaves the response untouched if
+// Content-Encoding is already set by a preceding middleware or handler.
+func Gzip(level int) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			gzw := &gzipResponseWriter{ResponseWriter: w, level: level}
+			defer gzw.close()
+
+			next.ServeHTTP(gzw, r)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package httpd
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGzipCompressesWhenAccepted(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(Gzip(gzip.DefaultCompression))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, []byte("hello world"))
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("expected Content-Encoding gzip, got %s", got)
+	}
+
+	gr, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := io.ReadAll(gr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(body) != "hello world" {
+		t.Errorf("expected body hello world, got %s", string(body))
+	}
+}
+
+func TestGzipSkippedWhenRefusedWithQZero(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(Gzip(gzip.DefaultCompression))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, []byte("hello world"))
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip;q=0")
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("expected no Content-Encoding when gzip is refused with q=0, got %s", got)
+	}
+
+	if w.Body.String() != "hello world" {
+		t.Errorf("expected body hello world, got %s", w.Body.String())
+	}
+}
+
+func TestGzipSkippedWithoutAcceptEncoding(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(Gzip(gzip.DefaultCompression))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, []byte("hello world"))
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("expected no Content-Encoding, got %s", got)
+	}
+
+	if w.Body.String() != "hello world" {
+		t.Errorf("expected body hello world, got %s", w.Body.String())
+	}
+}
+
+func TestGzipSkippedWhenAlreadyEncoded(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Use(Gzip(gzip.DefaultCompression))
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		w.Header().Set("Content-Encoding", "identity")
+		Respond(w, http.StatusOK, []byte("hello world"))
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	r.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Encoding"); got != "identity" {
+		t.Errorf("expected Content-Encoding identity, got %s", got)
+	}
+
+	if !strings.Contains(w.Body.String(), "hello world") {
+		t.Errorf("expected uncompressed body, got %q", w.Body.String())
+	}
+}
@@ -2,7 +2,6 @@ package httpd
 
 import (
 	"encoding/json"
-	"log/slog"
 	"net/http"
 )
 
@@ -21,7 +20,7 @@ func defaultErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
 		}
 	}
 
-	slog.Error("[httpd]", "err", err, "status", status)
+	Log.Error("[httpd]", "err", err, "status", status)
 
 	RespondJSON(
 		w,
@@ -137,10 +136,12 @@ func (r *Router) Use(middleware ...Middleware) {
 }
 
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	rw := NewResponseWriter(w)
+
 	var h http.Handler = r.mux
 	for i := len(r.mw) - 1; i >= 0; i-- {
 		h = r.mw[i](h)
 	}
 
-	h.ServeHTTP(w, req)
+	h.ServeHTTP(rw, req)
 }
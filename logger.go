@@ -0,0 +1,43 @@
+package httpd
+
+import "log/slog"
+
+// Logger is the logging interface used internally by httpd (request
+// logging, recovered panics, error handling). Assign a value satisfying
+// this interface to Log to redirect httpd's logs into an existing logger
+// (zap, zerolog, logrus, a test sink, etc).
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// Log is the Logger used by httpd internally. It defaults to an
+// slog-backed logger and can be reassigned, e.g. httpd.Log = myLogger.
+var Log Logger = NewSlogLogger(slog.Default())
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger adapts an *slog.Logger to the Logger interface.
+func NewSlogLogger(l *slog.Logger) Logger {
+	return &slogLogger{l: l}
+}
+
+func (s *slogLogger) Debug(msg string, args ...any) {
+	s.l.Debug(msg, args...)
+}
+
+func (s *slogLogger) Info(msg string, args ...any) {
+	s.l.Info(msg, args...)
+}
+
+func (s *slogLogger) Warn(msg string, args ...any) {
+	s.l.Warn(msg, args...)
+}
+
+func (s *slogLogger) Error(msg string, args ...any) {
+	s.l.Error(msg, args...)
+}
@@ -0,0 +1,91 @@
+package httpd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testLogger struct {
+	infos  []string
+	errors []string
+}
+
+func (l *testLogger) Debug(msg string, args ...any) {}
+
+func (l *testLogger) Info(msg string, args ...any) {
+	l.infos = append(l.infos, msg)
+}
+
+func (l *testLogger) Warn(msg string, args ...any) {}
+
+func (l *testLogger) Error(msg string, args ...any) {
+	l.errors = append(l.errors, msg)
+}
+
+func withTestLogger(t *testing.T) *testLogger {
+	t.Helper()
+
+	prev := Log
+	l := &testLogger{}
+	Log = l
+
+	t.Cleanup(func() {
+		Log = prev
+	})
+
+	return l
+}
+
+func TestLoggerMiddlewareCustomLogger(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	l := withTestLogger(t)
+
+	r := New()
+	r.Use(LoggerMiddleware)
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	r.ServeHTTP(w, req)
+
+	if len(l.infos) != 1 {
+		t.Fatalf("expected 1 info log entry, got %d", len(l.infos))
+	}
+
+	if !strings.Contains(l.infos[0], "GET") || !strings.Contains(l.infos[0], "/test") {
+		t.Errorf("expected request-line entry, got %q", l.infos[0])
+	}
+}
+
+func TestRecoverMiddlewareCustomLogger(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	l := withTestLogger(t)
+
+	r := New()
+	r.Use(RecoverMiddleware)
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		panic("test panic")
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	r.ServeHTTP(w, req)
+
+	if len(l.errors) != 2 {
+		t.Fatalf("expected 2 error log entries (recovered panic + error handler), got %d", len(l.errors))
+	}
+
+	if !strings.Contains(l.errors[0], "panic") {
+		t.Errorf("expected recovered-panic entry, got %q", l.errors[0])
+	}
+}
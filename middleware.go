@@ -2,7 +2,6 @@ package httpd
 
 import (
 	"fmt"
-	"log/slog"
 	"net/http"
 	"runtime/debug"
 	"time"
@@ -10,32 +9,9 @@ import (
 
 type Middleware func(http.Handler) http.Handler
 
-type responseWriter struct {
-	w      *http.ResponseWriter
-	status *int
-}
-
-func (r responseWriter) Header() http.Header {
-	return (*r.w).Header()
-}
-
-func (r responseWriter) Write(b []byte) (int, error) {
-	return (*r.w).Write(b)
-}
-
-func (r responseWriter) WriteHeader(status int) {
-	(*r.status) = status
-	(*r.w).WriteHeader(status)
-}
-
 func LoggerMiddleware(next http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		status := 0
-		rw := responseWriter{
-			w:      &w,
-			status: &status,
-		}
 
 		defer func() {
 			scheme := "http"
@@ -43,7 +19,25 @@ func LoggerMiddleware(next http.Handler) http.Handler {
 				scheme = "https"
 			}
 
-			slog.Info(
+			status := http.StatusOK
+			bytes := 0
+			if rw := GetResponseWriter(w); rw != nil {
+				status = rw.Status()
+				bytes = rw.BytesWritten()
+			}
+
+			args := []any{
+				"from", r.RemoteAddr,
+				"status", status,
+				"bytes", bytes,
+				"took", time.Since(start),
+			}
+
+			if id := GetRequestID(r); id != "" {
+				args = append(args, "request_id", id)
+			}
+
+			Log.Info(
 				fmt.Sprintf(
 					"[httpd] %s %s://%s%s %s",
 					r.Method,
@@ -52,13 +46,11 @@ func LoggerMiddleware(next http.Handler) http.Handler {
 					r.RequestURI,
 					r.Proto,
 				),
-				"from", r.RemoteAddr,
-				"status", *rw.status,
-				"took", time.Since(start),
+				args...,
 			)
 		}()
 
-		next.ServeHTTP(rw, r)
+		next.ServeHTTP(w, r)
 	}
 
 	return http.HandlerFunc(fn)
@@ -69,7 +61,7 @@ func RecoverMiddleware(next http.Handler) http.Handler {
 		defer func() {
 			if err := recover(); err != nil {
 				w.Header().Set("Connection", "close")
-				slog.Error("[httpd] recovering from panic", "err", err, "trace", debug.Stack())
+				Log.Error("[httpd] recovering from panic", "err", err, "trace", debug.Stack())
 				DefaultErrorHandler(w, r, fmt.Errorf("recovering from panic"))
 			}
 		}()
@@ -0,0 +1,50 @@
+package httpd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header RequestID reads an incoming request ID
+// from, and sets on the response.
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDContextKey struct{}
+
+// RequestID reads the request ID from the X-Request-ID header, generating
+// one if absent, sets it on the response, and stores it in the request
+// context for retrieval via GetRequestID (LoggerMiddleware also surfaces
+// it in request-line log entries when registered after RequestID).
+func RequestID() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(RequestIDHeader)
+			if id == "" {
+				id = generateRequestID()
+			}
+
+			w.Header().Set(RequestIDHeader, id)
+
+			ctx := context.WithValue(r.Context(), requestIDContextKey{}, id)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetRequestID returns the request ID stored in r's context by RequestID,
+// or "" if none is present.
+func GetRequestID(r *http.Request) string {
+	id, _ := r.Context().Value(requestIDContextKey{}).(string)
+	return id
+}
+
+func generateRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
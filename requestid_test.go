@@ -0,0 +1,60 @@
+package httpd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestIDGenerated(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	var seen string
+
+	r := New()
+	r.Use(RequestID())
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		seen = GetRequestID(req)
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	r.ServeHTTP(w, req)
+
+	if seen == "" {
+		t.Error("expected a generated request id in context")
+	}
+
+	if got := w.Header().Get(RequestIDHeader); got != seen {
+		t.Errorf("expected response header %s to match context value %s, got %s", RequestIDHeader, seen, got)
+	}
+}
+
+func TestRequestIDPropagated(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	var seen string
+
+	r := New()
+	r.Use(RequestID())
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		seen = GetRequestID(req)
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(RequestIDHeader, "my-request-id")
+	r.ServeHTTP(w, req)
+
+	if seen != "my-request-id" {
+		t.Errorf("expected request id my-request-id, got %s", seen)
+	}
+}
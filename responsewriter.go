@@ -0,0 +1,102 @@
+package httpd
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ResponseWriter wraps an http.ResponseWriter, tracking the status code and
+// number of bytes written so middlewares and handlers downstream of
+// Router.ServeHTTP can inspect them (e.g. LoggerMiddleware reads Status and
+// BytesWritten). It forwards Flush, Hijack, and Push to the wrapped writer
+// when it supports them, so it's safe to use for SSE, WebSocket upgrades,
+// and HTTP/2 push.
+type ResponseWriter struct {
+	http.ResponseWriter
+
+	status       int
+	bytesWritten int
+	written      bool
+}
+
+// NewResponseWriter wraps w in a ResponseWriter.
+func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
+	return &ResponseWriter{ResponseWriter: w}
+}
+
+// GetResponseWriter returns the ResponseWriter wrapping w, or nil if w is
+// not one (e.g. when called outside of a Router's handler chain).
+func GetResponseWriter(w http.ResponseWriter) *ResponseWriter {
+	rw, _ := w.(*ResponseWriter)
+	return rw
+}
+
+func (w *ResponseWriter) WriteHeader(status int) {
+	if w.written {
+		return
+	}
+
+	w.written = true
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *ResponseWriter) Write(b []byte) (int, error) {
+	if !w.written {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	n, err := w.ResponseWriter.Write(b)
+	w.bytesWritten += n
+
+	return n, err
+}
+
+// Status returns the response status code, defaulting to 200 if the
+// response has not been written yet.
+func (w *ResponseWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+// BytesWritten returns the number of response body bytes written so far.
+func (w *ResponseWriter) BytesWritten() int {
+	return w.bytesWritten
+}
+
+// Written reports whether the response headers have been written.
+func (w *ResponseWriter) Written() bool {
+	return w.written
+}
+
+// Flush implements http.Flusher if the wrapped writer does.
+func (w *ResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker if the wrapped writer does.
+func (w *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("httpd: underlying ResponseWriter does not support hijacking")
+	}
+
+	return h.Hijack()
+}
+
+// Push implements http.Pusher if the wrapped writer does.
+func (w *ResponseWriter) Push(target string, opts *http.PushOptions) error {
+	p, ok := w.ResponseWriter.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+
+	return p.Push(target, opts)
+}
@@ -0,0 +1,129 @@
+package httpd
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResponseWriterStatusAndBytesWritten(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		rw := GetResponseWriter(w)
+		if rw == nil {
+			t.Fatal("expected a ResponseWriter")
+		}
+
+		if rw.Written() {
+			t.Error("expected Written to be false before any write")
+		}
+
+		if _, err := w.Write([]byte("hello")); err != nil {
+			t.Fatal(err)
+		}
+
+		if rw.Status() != http.StatusOK {
+			t.Errorf("expected implicit status 200, got %d", rw.Status())
+		}
+
+		if rw.BytesWritten() != 5 {
+			t.Errorf("expected 5 bytes written, got %d", rw.BytesWritten())
+		}
+
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	r.ServeHTTP(w, req)
+}
+
+func TestResponseWriterPanicAfterPartialWrite(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	var status int
+
+	r := New()
+
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		w.Write([]byte("partial"))
+		panic("boom")
+	}, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			defer func() {
+				recover()
+				if rw := GetResponseWriter(w); rw != nil {
+					status = rw.Status()
+				}
+			}()
+			next.ServeHTTP(w, req)
+		})
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/test", nil)
+	r.ServeHTTP(w, req)
+
+	if status != http.StatusOK {
+		t.Errorf("expected status 200 to be recorded despite the panic, got %d", status)
+	}
+}
+
+type hijackableRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+	flushed  bool
+}
+
+func (h *hijackableRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.hijacked = true
+	server, _ := net.Pipe()
+	return server, bufio.NewReadWriter(bufio.NewReader(server), bufio.NewWriter(server)), nil
+}
+
+func (h *hijackableRecorder) Flush() {
+	h.flushed = true
+	h.ResponseRecorder.Flush()
+}
+
+func TestResponseWriterHijackAndFlush(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	base := &hijackableRecorder{ResponseRecorder: httptest.NewRecorder()}
+	rw := NewResponseWriter(base)
+
+	conn, _, err := rw.Hijack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if !base.hijacked {
+		t.Error("expected underlying writer to be hijacked")
+	}
+
+	rw.Flush()
+
+	if !base.flushed {
+		t.Error("expected underlying writer to be flushed")
+	}
+}
+
+func TestResponseWriterHijackUnsupported(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	rw := NewResponseWriter(httptest.NewRecorder())
+
+	if _, _, err := rw.Hijack(); err == nil {
+		t.Error("expected error when underlying writer does not support hijacking")
+	}
+}
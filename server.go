@@ -0,0 +1,149 @@
+package httpd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// Server wraps a Router with the lifecycle plumbing (listen, graceful
+// shutdown, OS signal handling) that most applications otherwise have to
+// wire up by hand around http.Server.
+type Server struct {
+	Router *Router
+
+	// ShutdownTimeout bounds how long Run waits for in-flight requests to
+	// drain before forcing the server closed. Defaults to 10 seconds.
+	ShutdownTimeout time.Duration
+
+	// OnStart hooks run, in order, right before the server starts
+	// listening.
+	OnStart []func()
+
+	// OnShutdown hooks run, in order, after the server has stopped
+	// accepting new connections (e.g. to close a DB pool or flush a
+	// logger).
+	OnShutdown []func()
+
+	// httpServer is built once, in NewServer, and its pointer never
+	// changes afterwards — only Start/StartTLS/StartAutoTLS configure its
+	// Addr/TLSConfig before serving. This keeps it non-nil and safe to
+	// read from Shutdown regardless of which goroutine is driving Start
+	// (e.g. when Start is run inside the goroutine Run spawns): there is
+	// no field reassignment for the race detector to catch, and Shutdown
+	// never sees a server that hasn't been constructed yet.
+	httpServer *http.Server
+}
+
+// NewServer creates a Server that serves r.
+func NewServer(r *Router) *Server {
+	return &Server{
+		Router:          r,
+		ShutdownTimeout: 10 * time.Second,
+		httpServer:      &http.Server{Handler: r},
+	}
+}
+
+func (s *Server) runHooks(hooks []func()) {
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+func (s *Server) listen(serve func() error) error {
+	s.runHooks(s.OnStart)
+
+	if err := serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Start starts the server listening for plain HTTP on addr. It blocks until
+// the server is shut down via Shutdown/Run or fails to start.
+func (s *Server) Start(addr string) error {
+	s.httpServer.Addr = addr
+
+	return s.listen(s.httpServer.ListenAndServe)
+}
+
+// StartTLS starts the server listening for HTTPS on addr using the given
+// certificate and key files. It blocks until the server is shut down via
+// Shutdown/Run or fails to start.
+func (s *Server) StartTLS(addr string, certFile string, keyFile string) error {
+	s.httpServer.Addr = addr
+
+	return s.listen(func() error {
+		return s.httpServer.ListenAndServeTLS(certFile, keyFile)
+	})
+}
+
+// StartAutoTLS starts the server listening for HTTPS on addr, provisioning
+// and renewing certificates automatically via ACME (Let's Encrypt) for hosts
+// allowed by hostPolicy. It blocks until the server is shut down via
+// Shutdown/Run or fails to start.
+func (s *Server) StartAutoTLS(addr string, hostPolicy autocert.HostPolicy) error {
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: hostPolicy,
+		Cache:      autocert.DirCache("certs"),
+	}
+
+	s.httpServer.Addr = addr
+	s.httpServer.TLSConfig = m.TLSConfig()
+
+	return s.listen(func() error {
+		return s.httpServer.ListenAndServeTLS("", "")
+	})
+}
+
+// Shutdown gracefully stops the server: it stops accepting new connections
+// and waits for in-flight requests to finish before ctx is done, then runs
+// the OnShutdown hooks. If ctx is done first, the remaining connections are
+// closed and ctx's error is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	defer s.runHooks(s.OnShutdown)
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.httpServer.Close()
+		return err
+	}
+
+	return nil
+}
+
+// Run starts the server via start (typically s.Start, s.StartTLS, or
+// s.StartAutoTLS called in a closure) and blocks until ctx is canceled or a
+// SIGINT/SIGTERM is received, at which point it gracefully shuts the server
+// down, allowing up to ShutdownTimeout for in-flight requests to drain.
+func (s *Server) Run(ctx context.Context, start func() error) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- start()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+
+	return <-errCh
+}
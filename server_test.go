@@ -0,0 +1,140 @@
+package httpd
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	return l.Addr().String()
+}
+
+func waitForDial(t *testing.T, addr string) {
+	t.Helper()
+
+	for i := 0; i < 100; i++ {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("server at %s never came up", addr)
+}
+
+func TestServerRun(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	r.Get("/test", func(w http.ResponseWriter, req *http.Request) error {
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	s := NewServer(r)
+	addr := freeAddr(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- s.Run(ctx, func() error {
+			return s.Start(addr)
+		})
+	}()
+
+	waitForDial(t, addr)
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Errorf("expected clean exit, got %v", err)
+	}
+}
+
+func TestServerShutdownForcedClose(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	release := make(chan struct{})
+	r := New()
+
+	r.Get("/slow", func(w http.ResponseWriter, req *http.Request) error {
+		<-release
+		Respond(w, http.StatusOK, nil)
+		return nil
+	})
+
+	s := NewServer(r)
+	addr := freeAddr(t)
+
+	go s.Start(addr)
+	waitForDial(t, addr)
+
+	reqDone := make(chan struct{})
+	go func() {
+		if resp, err := http.Get("http://" + addr + "/slow"); err == nil {
+			resp.Body.Close()
+		}
+		close(reqDone)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := s.Shutdown(ctx); err == nil {
+		t.Error("expected shutdown to be forced by timeout while a request was in flight")
+	}
+
+	close(release)
+	<-reqDone
+}
+
+func TestServerHooks(t *testing.T) {
+	setup(t)
+	defer teardown(t)
+
+	r := New()
+	s := NewServer(r)
+	addr := freeAddr(t)
+
+	started := false
+	s.OnStart = append(s.OnStart, func() {
+		started = true
+	})
+
+	stopped := false
+	s.OnShutdown = append(s.OnShutdown, func() {
+		stopped = true
+	})
+
+	go s.Start(addr)
+	waitForDial(t, addr)
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !started {
+		t.Error("expected OnStart hook to run")
+	}
+
+	if !stopped {
+		t.Error("expected OnShutdown hook to run")
+	}
+}